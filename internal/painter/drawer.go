@@ -33,6 +33,18 @@ func tabStop(f font.Face, x fixed.Int26_6) fixed.Int26_6 {
 	return tabw * fixed.Int26_6(tabs)
 }
 
+// wideAdvance forces a wide rune to advance by exactly twice a space's
+// width rather than trusting the font's native glyph metrics, so the same
+// rune always measures the same whether it is drawn by this Drawer or laid
+// out as a cell of a widget.TextGrid.
+func wideAdvance(f font.Face, natural fixed.Int26_6) fixed.Int26_6 {
+	spacew, ok := f.GlyphAdvance(' ')
+	if !ok {
+		return natural
+	}
+	return 2 * spacew
+}
+
 // DrawString draws s at the dot and advances the dot's location.
 // Tabs are translated into a dot location change.
 func (d *Drawer) DrawString(s string) {
@@ -52,6 +64,9 @@ func (d *Drawer) DrawString(s string) {
 				continue
 			}
 			draw.DrawMask(d.Dst, dr, d.Src, image.Point{}, mask, maskp, draw.Over)
+			if RuneWidth(c) == 2 {
+				a = wideAdvance(d.Face, a)
+			}
 			d.Dot.X += a
 		}
 
@@ -77,6 +92,9 @@ func MeasureString(f font.Face, s string) (advance fixed.Int26_6) {
 				// TODO: set prevC = '\ufffd'?
 				continue
 			}
+			if RuneWidth(c) == 2 {
+				a = wideAdvance(f, a)
+			}
 			advance += a
 		}
 
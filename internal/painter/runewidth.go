@@ -0,0 +1,55 @@
+package painter
+
+// runeWidthRange is an inclusive [lo, hi] range of runes sharing a display
+// width, used by RuneWidth.
+type runeWidthRange struct {
+	lo, hi rune
+}
+
+// wideRuneRanges is a condensed table of the Unicode ranges whose East Asian
+// Width property is Wide (W) or Fullwidth (F), plus the common emoji
+// presentation blocks. Ranges are sorted by lo so RuneWidth can stop
+// scanning early.
+//
+// This is the single source of truth for rune width - widget.RuneWidth
+// calls through to this package rather than keeping its own copy, so label
+// text and widget.TextGrid cells can never drift out of agreement.
+var wideRuneRanges = []runeWidthRange{
+	{0x1100, 0x115F},   // Hangul Jamo
+	{0x2329, 0x232A},   // Angle brackets
+	{0x2E80, 0x303E},   // CJK Radicals, Kangxi Radicals, CJK Symbols and Punctuation
+	{0x3041, 0x33FF},   // Hiragana .. CJK Compatibility
+	{0x3400, 0x4DBF},   // CJK Unified Ideographs Extension A
+	{0x4E00, 0x9FFF},   // CJK Unified Ideographs
+	{0xA000, 0xA4CF},   // Yi Syllables and Radicals
+	{0xAC00, 0xD7A3},   // Hangul Syllables
+	{0xF900, 0xFAFF},   // CJK Compatibility Ideographs
+	{0xFE30, 0xFE4F},   // CJK Compatibility Forms
+	{0xFF00, 0xFF60},   // Fullwidth Forms
+	{0xFFE0, 0xFFE6},   // Fullwidth Signs
+	{0x1F300, 0x1F64F}, // Misc Symbols and Pictographs, Emoticons
+	{0x1F680, 0x1F6FF}, // Transport and Map Symbols
+	{0x1F900, 0x1F9FF}, // Supplemental Symbols and Pictographs
+	{0x20000, 0x3FFFD}, // CJK Unified Ideographs Extension B and beyond
+}
+
+// RuneWidth returns the number of fixed-width cells r should advance: 2 for
+// wide East Asian characters and most emoji, 1 for everything else. Drawer
+// and MeasureString use this so that a wide rune always advances by exactly
+// twice a space's width, regardless of what the font's own glyph metrics
+// say - keeping label text and widget.TextGrid cells in agreement.
+func RuneWidth(r rune) int {
+	if r < wideRuneRanges[0].lo {
+		return 1
+	}
+
+	for _, rng := range wideRuneRanges {
+		if r < rng.lo {
+			break
+		}
+		if r <= rng.hi {
+			return 2
+		}
+	}
+	return 1
+}
@@ -0,0 +1,14 @@
+package widget
+
+import "fyne.io/fyne/internal/painter"
+
+// RuneWidth returns the number of monospace grid cells r should occupy: 2
+// for wide East Asian characters and most emoji, 1 for everything else.
+// TextGrid uses this to keep a row's visual columns aligned with its
+// content when Latin and CJK/emoji text are mixed. It defers to
+// internal/painter's table rather than keeping its own copy, so this
+// package and the font drawing code never disagree about how wide a rune
+// is.
+func RuneWidth(r rune) int {
+	return painter.RuneWidth(r)
+}
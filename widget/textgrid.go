@@ -66,6 +66,24 @@ type TextGrid struct {
 
 	LineNumbers bool
 	Whitespace  bool
+
+	// OnRegionTapped is called when the user clicks inside a range of cells
+	// previously marked with SetRegion.
+	OnRegionTapped func(name string)
+
+	ansi      *ansiState
+	cellSize  fyne.Size
+	selection gridSelection
+	regions   []textGridRegion
+
+	// renderer is the textGridRender created by CreateRenderer, kept so that
+	// refreshRange can repaint only the rows it touched without going
+	// through the widget's public Refresh(). Refresh() is also reachable by
+	// a caller that edited the exported Content slice directly, or by a
+	// theme change, and neither of those carries row information - routing
+	// a scoped repaint through that same generic entry point would let an
+	// unrelated Refresh() call consume a stale, too-narrow pending range.
+	renderer *textGridRender
 }
 
 // MinSize returns the smallest size this widget can shrink to
@@ -77,7 +95,7 @@ func (t *TextGrid) MinSize() fyne.Size {
 // Resize is called when this widget changes size. We should make sure that we refresh cells.
 func (t *TextGrid) Resize(size fyne.Size) {
 	t.BaseWidget.Resize(size)
-	t.Refresh()
+	t.refreshAll()
 }
 
 // SetText updates the buffer of this textgrid to contain the specified text.
@@ -95,7 +113,7 @@ func (t *TextGrid) SetText(text string) {
 	}
 
 	t.Content = buffer
-	t.Refresh()
+	t.refreshAll()
 }
 
 // Text returns the contents of the buffer as a single string (with no style information).
@@ -136,10 +154,39 @@ func (t *TextGrid) SetRow(row int, content []TextGridCell) {
 	}
 
 	t.Content[row] = content
-	t.Refresh()
+	t.refreshRange(row, row)
 }
 
-// SetStyle sets a grid style to the cell at named row and column
+// rowVisualWidth returns the number of visual columns a row occupies,
+// counting wide (e.g. CJK) runes as two columns.
+func rowVisualWidth(row []TextGridCell) int {
+	width := 0
+	for _, cell := range row {
+		width += RuneWidth(cell.Rune)
+	}
+	return width
+}
+
+// visualColumnIndex returns the index into content of the cell covering
+// visual column col, accounting for any preceding wide runes - both
+// visual columns of a wide rune map to the same index. If col falls beyond
+// the row's current visual width, the index where a new column-wide cell
+// would belong is returned.
+func visualColumnIndex(content []TextGridCell, col int) int {
+	visual := 0
+	for idx, cell := range content {
+		width := RuneWidth(cell.Rune)
+		if col < visual+width {
+			return idx
+		}
+		visual += width
+	}
+	return len(content) + (col - visual)
+}
+
+// SetStyle sets a grid style to the cell at the named row and visual
+// column. If that column is the second half of a wide (e.g. CJK) rune, the
+// style is applied to the single cell that rune occupies.
 func (t *TextGrid) SetStyle(row, col int, style TextGridStyle) {
 	if row < 0 || col < 0 {
 		return
@@ -149,10 +196,12 @@ func (t *TextGrid) SetStyle(row, col int, style TextGridStyle) {
 	}
 	content := t.Content[row]
 
-	for len(content) <= col {
+	idx := visualColumnIndex(content, col)
+	for len(content) <= idx {
 		content = append(content, TextGridCell{})
 	}
-	content[col].Style = style
+	content[idx].Style = style
+	t.Content[row] = content
 }
 
 // SetStyleRange sets a grid style to all the cells between the start row and column through to the end row and column.
@@ -161,17 +210,18 @@ func (t *TextGrid) SetStyleRange(startRow, startCol, endRow, endCol int, style T
 		for col := startCol; col <= endCol; col++ {
 			t.SetStyle(startRow, col, style)
 		}
+		t.refreshRange(startRow, endRow)
 		return
 	}
 
 	// first row
-	for col := startCol; col < len(t.Content[startRow]); col++ {
+	for col := startCol; col < rowVisualWidth(t.Content[startRow]); col++ {
 		t.SetStyle(startRow, col, style)
 	}
 
 	// possible middle rows
 	for rowNum := startRow + 1; rowNum < endRow-1; rowNum++ {
-		for col := 0; col < len(t.Content[rowNum]); col++ {
+		for col := 0; col < rowVisualWidth(t.Content[rowNum]); col++ {
 			t.SetStyle(rowNum, col, style)
 		}
 	}
@@ -180,16 +230,48 @@ func (t *TextGrid) SetStyleRange(startRow, startCol, endRow, endCol int, style T
 	for col := 0; col <= endCol; col++ {
 		t.SetStyle(endRow, col, style)
 	}
+
+	t.refreshRange(startRow, endRow)
+}
+
+// refreshRange asks the renderer to repaint only rows startRow through
+// endRow (inclusive), bypassing Refresh() so that an unrelated later call to
+// Refresh() (a theme change, or a caller that edited Content directly) can
+// never mistake this scoped repaint for the whole grid. If no renderer
+// exists yet (the widget has not been shown), there is nothing to scope -
+// the eventual first Layout/Refresh will paint everything anyway.
+func (t *TextGrid) refreshRange(startRow, endRow int) {
+	if startRow < 0 {
+		startRow = 0
+	}
+	if endRow < startRow {
+		endRow = startRow
+	}
+
+	if t.renderer == nil {
+		return
+	}
+	t.renderer.ensureGrid()
+	t.renderer.refreshRows(startRow, endRow)
+}
+
+// refreshAll asks the renderer to repaint the whole grid, for changes (like
+// SetText) that can alter every row, and for anything - a theme change, a
+// resize, a direct edit of Content - that doesn't know which rows changed.
+func (t *TextGrid) refreshAll() {
+	t.Refresh()
 }
 
 // CreateRenderer is a private method to Fyne which links this widget to it's renderer
 func (t *TextGrid) CreateRenderer() fyne.WidgetRenderer {
 	t.ExtendBaseWidget(t)
 	render := &textGridRender{text: t}
+	t.renderer = render
 
 	cell := canvas.NewText("M", color.White)
 	cell.TextStyle.Monospace = true
 	render.cellSize = cell.MinSize()
+	t.cellSize = render.cellSize
 
 	return render
 }
@@ -208,6 +290,15 @@ func NewTextGridFromString(content string) *TextGrid {
 	return grid
 }
 
+// cellSnapshot is the last (rune, fg, bg, bold, italic) drawn for a cell, so
+// Refresh can skip any cell whose appearance has not actually changed.
+type cellSnapshot struct {
+	valid        bool
+	rune         rune
+	fg, bg       color.Color
+	bold, italic bool
+}
+
 type textGridRender struct {
 	text *TextGrid
 
@@ -215,6 +306,7 @@ type textGridRender struct {
 
 	cellSize fyne.Size
 	objects  []fyne.CanvasObject
+	drawn    []cellSnapshot
 }
 
 func (t *textGridRender) appendTextCell(str rune) {
@@ -223,99 +315,174 @@ func (t *textGridRender) appendTextCell(str rune) {
 
 	bg := canvas.NewRectangle(color.Transparent)
 	t.objects = append(t.objects, bg, text)
+	t.drawn = append(t.drawn, cellSnapshot{})
+}
+
+// ensureCell grows objects (and the parallel drawn cache) so that pos is
+// addressable. Cells are only ever appended, never removed, so a grid that
+// has grown large (e.g. a long-running terminal) keeps its allocated
+// objects even if Content later shrinks back down.
+//
+// Note this does not give per-row lazy allocation: ensureGrid below still
+// calls this with the last cell of the full t.rows*t.cols grid, because
+// Layout positions every cell of that grid on every call and has no notion
+// of a scrolled viewport smaller than the buffer. The saving from caching
+// drawn cells in setCellRune is in skipped repaints, not skipped allocation.
+func (t *textGridRender) ensureCell(pos int) {
+	for len(t.objects) <= pos*2+1 {
+		t.appendTextCell(' ')
+	}
 }
 
 func (t *textGridRender) setCellRune(str rune, pos int, style TextGridStyle) {
-	rect := t.objects[pos*2].(*canvas.Rectangle)
-	text := t.objects[pos*2+1].(*canvas.Text)
-	if str == 0 {
-		text.Text = " "
-	} else {
-		text.Text = string(str)
-	}
+	t.ensureCell(pos)
 
 	fg := theme.TextColor()
 	if style != nil && style.TextColor() != nil {
 		fg = style.TextColor()
 	}
-	text.Color = fg
+
+	textStyle := fyne.TextStyle{Monospace: true}
+	if styled, ok := style.(interface{ TextStyle() fyne.TextStyle }); ok {
+		s := styled.TextStyle()
+		textStyle.Bold = s.Bold
+		textStyle.Italic = s.Italic
+	}
 
 	bg := color.Color(color.Transparent)
 	if style != nil && style.BackgroundColor() != nil {
 		bg = style.BackgroundColor()
 	}
+
+	snap := cellSnapshot{valid: true, rune: str, fg: fg, bg: bg, bold: textStyle.Bold, italic: textStyle.Italic}
+	if t.drawn[pos] == snap {
+		return // unchanged since the last paint - nothing to redraw
+	}
+	t.drawn[pos] = snap
+
+	rect := t.objects[pos*2].(*canvas.Rectangle)
+	text := t.objects[pos*2+1].(*canvas.Text)
+	if str == 0 {
+		text.Text = " "
+	} else {
+		text.Text = string(str)
+	}
+	text.Color = fg
+	text.TextStyle = textStyle
 	rect.FillColor = bg
+
+	canvas.Refresh(text)
+	canvas.Refresh(rect)
 }
 
 func (t *textGridRender) ensureGrid() {
 	cellCount := t.cols * t.rows
-	if len(t.objects) == cellCount*2 {
-		return
-	}
-	for i := len(t.objects); i < cellCount*2; i += 2 {
-		t.appendTextCell(' ')
+	if cellCount > 0 {
+		t.ensureCell(cellCount - 1)
 	}
 }
 
-func (t *textGridRender) refreshGrid() {
-	line := 1
-	x := 0
-
-	for rowIndex, row := range t.text.Content {
-		if rowIndex >= t.rows { // would be an overflow - bad
-			break
-		}
-		i := 0
-		if t.text.LineNumbers {
-			lineStr := []rune(fmt.Sprintf("%d", line))
-			for c := 0; c < len(lineStr); c++ {
-				t.setCellRune(lineStr[c], x, TextGridStyleWhitespace) // line numbers
-				i++
-				x++
-			}
-			for ; i < t.lineCountWidth(); i++ {
-				t.setCellRune(' ', x, TextGridStyleWhitespace) // padding space
-				x++
-			}
+// paintRow draws one buffer row, including its line-number prefix and
+// trailing blanks, starting at the flat cell index rowIndex*t.cols.
+func (t *textGridRender) paintRow(rowIndex int) {
+	row := t.text.Content[rowIndex]
+	x := rowIndex * t.cols
+	i := 0
 
-			t.setCellRune('|', x, TextGridStyleWhitespace) // last space
+	if t.text.LineNumbers {
+		lineStr := []rune(fmt.Sprintf("%d", rowIndex+1))
+		for c := 0; c < len(lineStr); c++ {
+			t.setCellRune(lineStr[c], x, TextGridStyleWhitespace) // line numbers
 			i++
 			x++
 		}
-		for _, r := range row {
-			if i >= t.cols { // would be an overflow - bad
-				continue
-			}
-			if t.text.Whitespace && r.Rune == ' ' {
-				if r.Style != nil && r.Style.BackgroundColor() != nil {
-					whitespaceBG := &CustomTextGridStyle{FGColor: TextGridStyleWhitespace.TextColor(),
-						BGColor: r.Style.BackgroundColor()}
-					t.setCellRune(textAreaSpaceSymbol, x, whitespaceBG) // whitespace char
-				} else {
-					t.setCellRune(textAreaSpaceSymbol, x, TextGridStyleWhitespace) // whitespace char
-				}
-			} else {
-				t.setCellRune(r.Rune, x, r.Style) // regular char
-			}
-			i++
+		for ; i < t.lineCountWidth(); i++ {
+			t.setCellRune(' ', x, TextGridStyleWhitespace) // padding space
 			x++
 		}
-		if t.text.Whitespace && i < t.cols && rowIndex < len(t.text.Content)-1 {
-			t.setCellRune(textAreaNewLineSymbol, x, TextGridStyleWhitespace) // newline
-			i++
-			x++
+
+		t.setCellRune('|', x, TextGridStyleWhitespace) // last space
+		i++
+		x++
+	}
+	visualCol := 0
+	for _, r := range row {
+		width := RuneWidth(r.Rune)
+		if i >= t.cols { // would be an overflow - bad
+			visualCol += width
+			continue
 		}
-		for ; i < t.cols; i++ {
-			t.setCellRune(' ', x, TextGridStyleDefault) // blanks
-			x++
+		selected := t.text.selection.contains(rowIndex, visualCol)
+		if t.text.Whitespace && r.Rune == ' ' {
+			var style TextGridStyle = TextGridStyleWhitespace
+			if r.Style != nil && r.Style.BackgroundColor() != nil {
+				style = &CustomTextGridStyle{FGColor: TextGridStyleWhitespace.TextColor(),
+					BGColor: r.Style.BackgroundColor()}
+			}
+			if selected {
+				style = selectionStyle{base: style}
+			}
+			t.setCellRune(textAreaSpaceSymbol, x, style) // whitespace char
+		} else {
+			var style TextGridStyle = r.Style
+			if selected {
+				style = selectionStyle{base: style}
+			}
+			t.setCellRune(r.Rune, x, style) // regular char
+			if width == 2 && i+1 < t.cols {
+				t.setCellRune(0, x+1, style) // ghost cell - second half of a wide rune
+				i++
+				x++
+			}
+		}
+		i++
+		x++
+		visualCol += width
+	}
+	if t.text.Whitespace && i < t.cols && rowIndex < len(t.text.Content)-1 {
+		t.setCellRune(textAreaNewLineSymbol, x, TextGridStyleWhitespace) // newline
+		i++
+		x++
+	}
+	for ; i < t.cols; i++ {
+		t.setCellRune(' ', x, TextGridStyleDefault) // blanks
+		x++
+	}
+}
+
+// blankTrailingRows clears any rows beyond the buffer's content, up to the
+// last allocated cell - for example when the viewport is taller than the
+// number of lines currently in the grid.
+func (t *textGridRender) blankTrailingRows(fromRow int) {
+	for x := fromRow * t.cols; x < len(t.objects)/2; x++ {
+		t.setCellRune(' ', x, TextGridStyleDefault)
+	}
+}
+
+func (t *textGridRender) refreshGrid() {
+	for rowIndex := range t.text.Content {
+		if rowIndex >= t.rows { // would be an overflow - bad
+			break
 		}
+		t.paintRow(rowIndex)
+	}
+	t.blankTrailingRows(len(t.text.Content))
+}
 
-		line++
+// refreshRows repaints only rows startRow through endRow (inclusive),
+// skipping the full-grid scan refreshGrid would otherwise do.
+func (t *textGridRender) refreshRows(startRow, endRow int) {
+	if startRow < 0 {
+		startRow = 0
 	}
-	for ; x < len(t.objects)/2; x++ {
-		t.setCellRune(' ', x, TextGridStyleDefault) // blank lines?
+	lastRow := len(t.text.Content) - 1
+	if endRow > lastRow {
+		endRow = lastRow
+	}
+
+	for rowIndex := startRow; rowIndex <= endRow && rowIndex < t.rows; rowIndex++ {
+		t.paintRow(rowIndex)
 	}
-	canvas.Refresh(t.text)
 }
 
 func (t *textGridRender) lineCountWidth() int {
@@ -326,7 +493,7 @@ func (t *textGridRender) updateGridSize(size fyne.Size) {
 	bufRows := len(t.text.Content)
 	bufCols := 0
 	for _, row := range t.text.Content {
-		bufCols = int(math.Max(float64(bufCols), float64(len(row))))
+		bufCols = int(math.Max(float64(bufCols), float64(rowVisualWidth(row))))
 	}
 	sizeCols := int(math.Floor(float64(size.Width) / float64(t.cellSize.Width)))
 	sizeRows := int(math.Floor(float64(size.Height) / float64(t.cellSize.Height)))
@@ -367,12 +534,20 @@ func (t *textGridRender) MinSize() fyne.Size {
 	return fyne.NewSize(t.cellSize.Width*t.cols, t.cellSize.Height*t.rows)
 }
 
+// Refresh always repaints the whole grid. Scoped repaints of specific rows
+// go through refreshRows directly (see TextGrid.refreshRange) rather than
+// through here, since this is also what runs for a theme change or for a
+// caller that edited Content directly and called Refresh() itself - neither
+// of which knows which rows actually changed.
 func (t *textGridRender) Refresh() {
 	t.ensureGrid()
 	t.refreshGrid()
 }
 
+// ApplyTheme repaints every cell so the new theme's colours take effect
+// immediately, rather than waiting for some later, unrelated Refresh call.
 func (t *textGridRender) ApplyTheme() {
+	t.refreshGrid()
 }
 
 func (t *textGridRender) BackgroundColor() color.Color {
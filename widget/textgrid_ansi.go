@@ -0,0 +1,321 @@
+package widget
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"fyne.io/fyne/theme"
+)
+
+// ansi16 is the standard 16-colour ANSI palette (indices 0-7 normal, 8-15 bright).
+// Black and white are tied to the current theme so that the palette remains
+// legible against both light and dark backgrounds.
+func ansi16(index int, bright bool) color.Color {
+	if bright {
+		index += 8
+	}
+
+	switch index {
+	case 0:
+		return theme.BackgroundColor()
+	case 1:
+		return color.RGBA{R: 0xcd, G: 0x00, B: 0x00, A: 0xff}
+	case 2:
+		return color.RGBA{R: 0x00, G: 0xcd, B: 0x00, A: 0xff}
+	case 3:
+		return color.RGBA{R: 0xcd, G: 0xcd, B: 0x00, A: 0xff}
+	case 4:
+		return color.RGBA{R: 0x00, G: 0x00, B: 0xee, A: 0xff}
+	case 5:
+		return color.RGBA{R: 0xcd, G: 0x00, B: 0xcd, A: 0xff}
+	case 6:
+		return color.RGBA{R: 0x00, G: 0xcd, B: 0xcd, A: 0xff}
+	case 7:
+		return theme.TextColor()
+	case 8:
+		return color.RGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0xff}
+	case 9:
+		return color.RGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+	case 10:
+		return color.RGBA{R: 0x00, G: 0xff, B: 0x00, A: 0xff}
+	case 11:
+		return color.RGBA{R: 0xff, G: 0xff, B: 0x00, A: 0xff}
+	case 12:
+		return color.RGBA{R: 0x5c, G: 0x5c, B: 0xff, A: 0xff}
+	case 13:
+		return color.RGBA{R: 0xff, G: 0x00, B: 0xff, A: 0xff}
+	case 14:
+		return color.RGBA{R: 0x00, G: 0xff, B: 0xff, A: 0xff}
+	default:
+		return color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	}
+}
+
+// ansi256 resolves an index from the xterm 256-colour palette: 0-15 are the
+// standard/bright colours, 16-231 are a 6x6x6 colour cube and 232-255 are a
+// grayscale ramp.
+func ansi256(n int) color.Color {
+	switch {
+	case n < 8:
+		return ansi16(n, false)
+	case n < 16:
+		return ansi16(n-8, true)
+	case n < 232:
+		n -= 16
+		levels := [6]uint8{0x00, 0x5f, 0x87, 0xaf, 0xd7, 0xff}
+		r := levels[(n/36)%6]
+		g := levels[(n/6)%6]
+		b := levels[n%6]
+		return color.RGBA{R: r, G: g, B: b, A: 0xff}
+	default:
+		v := uint8(8 + (n-232)*10)
+		return color.RGBA{R: v, G: v, B: v, A: 0xff}
+	}
+}
+
+// ansiShade lightens (amt > 0) or darkens (amt < 0) c, used to approximate
+// the effect of the bold and faint SGR attributes on the foreground colour.
+func ansiShade(c color.Color, amt float64) color.Color {
+	r, g, b, a := c.RGBA()
+	shade := func(v uint32) uint8 {
+		f := float64(v>>8) + amt*255
+		if f < 0 {
+			f = 0
+		}
+		if f > 255 {
+			f = 255
+		}
+		return uint8(f)
+	}
+	return color.RGBA{R: shade(r), G: shade(g), B: shade(b), A: uint8(a >> 8)}
+}
+
+// ansiState tracks the in-progress SGR attributes and cursor position used
+// while streaming terminal output into a TextGrid.
+type ansiState struct {
+	row, col    int
+	fg, bg      color.Color
+	bold, faint bool
+	pending     []byte // bytes held back because they may be an incomplete rune or escape
+}
+
+func (t *TextGrid) style() *ansiState {
+	if t.ansi == nil {
+		t.ansi = &ansiState{}
+	}
+	return t.ansi
+}
+
+func (s *ansiState) cellStyle() TextGridStyle {
+	if s.fg == nil && s.bg == nil && !s.bold && !s.faint {
+		return nil
+	}
+
+	fg := s.fg
+	if fg == nil {
+		fg = theme.TextColor()
+	}
+	if s.bold {
+		fg = ansiShade(fg, 0.35)
+	} else if s.faint {
+		fg = ansiShade(fg, -0.35)
+	}
+
+	return &CustomTextGridStyle{FGColor: fg, BGColor: s.bg}
+}
+
+func (s *ansiState) reset() {
+	s.fg, s.bg = nil, nil
+	s.bold, s.faint = false, false
+}
+
+// SetANSI replaces the content of the grid by interpreting text as a stream
+// of terminal output, translating SGR ("\x1b[...m") escape sequences into
+// per-cell TextGridStyle values. It is a convenience wrapper around
+// WriteANSI for callers that already have the whole buffer in memory.
+func (t *TextGrid) SetANSI(text string) {
+	t.Content = nil
+	t.ansi = &ansiState{}
+
+	_, _ = t.WriteANSI([]byte(text))
+}
+
+// Write feeds a chunk of terminal output (raw bytes, as might be read from a
+// subprocess PTY) into the grid, appending to whatever was already there.
+// Its signature matches io.Writer so a TextGrid can be driven directly by
+// code that streams process output, for example io.Copy(grid, ptyReader).
+// Escape sequences that are split across calls are buffered until they can
+// be completed.
+func (t *TextGrid) Write(p []byte) (int, error) {
+	s := t.style()
+	buf := append(s.pending, p...)
+
+	i := 0
+	for i < len(buf) {
+		if buf[i] == 0x1b {
+			end, ok := ansiEscapeEnd(buf[i:])
+			if !ok {
+				break // incomplete escape sequence - wait for more data
+			}
+			t.applyANSIEscape(buf[i : i+end])
+			i += end
+			continue
+		}
+
+		r, size := utf8.DecodeRune(buf[i:])
+		if r == utf8.RuneError && size == 1 && !utf8.FullRune(buf[i:]) {
+			break // possibly a rune split across writes - wait for more data
+		}
+
+		switch r {
+		case '\n':
+			s.row++
+			s.col = 0
+		case '\r':
+			s.col = 0
+		case '\b':
+			if s.col > 0 {
+				s.col--
+			}
+		default:
+			t.putRune(s.row, s.col, r, s.cellStyle())
+			s.col++
+		}
+		i += size
+	}
+
+	s.pending = append([]byte{}, buf[i:]...)
+	t.refreshAll()
+	return len(p), nil
+}
+
+// WriteANSI is an alias for Write, kept for callers that want the more
+// descriptive name when not using the grid as an io.Writer directly.
+func (t *TextGrid) WriteANSI(p []byte) (int, error) {
+	return t.Write(p)
+}
+
+// putRune writes a single rune and style into the content buffer, expanding
+// rows and columns as required - mirroring the growth behaviour of
+// SetStyle. It is shared by the ANSI and rich-text parsers.
+func (t *TextGrid) putRune(row, col int, r rune, style TextGridStyle) {
+	for len(t.Content) <= row {
+		t.Content = append(t.Content, []TextGridCell{})
+	}
+	content := t.Content[row]
+	for len(content) <= col {
+		content = append(content, TextGridCell{})
+	}
+	content[col] = TextGridCell{Rune: r, Style: style}
+	t.Content[row] = content
+}
+
+// ansiEscapeEnd returns the length of the escape sequence at the start of
+// buf (including the initial ESC byte) and whether it is complete. Only
+// CSI sequences ("\x1b[...X") are treated specially; any other two-byte
+// escape is considered complete immediately so it can be discarded.
+func ansiEscapeEnd(buf []byte) (int, bool) {
+	if len(buf) < 2 {
+		return 0, false
+	}
+	if buf[1] != '[' {
+		return 2, true
+	}
+
+	for i := 2; i < len(buf); i++ {
+		if buf[i] >= 0x40 && buf[i] <= 0x7e {
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+// applyANSIEscape interprets a complete escape sequence, updating the
+// current style. Anything that is not a recognised SGR sequence is
+// silently dropped so it cannot corrupt surrounding text.
+func (t *TextGrid) applyANSIEscape(seq []byte) {
+	if len(seq) < 3 || seq[len(seq)-1] != 'm' {
+		return
+	}
+
+	params := strings.Split(string(seq[2:len(seq)-1]), ";")
+	codes := make([]int, 0, len(params))
+	for _, p := range params {
+		if p == "" {
+			codes = append(codes, 0)
+			continue
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return // malformed parameter - drop the whole sequence
+		}
+		codes = append(codes, n)
+	}
+	if len(codes) == 0 {
+		codes = []int{0}
+	}
+
+	s := t.style()
+	for i := 0; i < len(codes); i++ {
+		code := codes[i]
+		switch {
+		case code == 0:
+			s.reset()
+		case code == 1:
+			s.bold, s.faint = true, false
+		case code == 2:
+			s.faint, s.bold = true, false
+		case code == 22:
+			s.bold, s.faint = false, false
+		case code == 39:
+			s.fg = nil
+		case code == 49:
+			s.bg = nil
+		case code >= 30 && code <= 37:
+			s.fg = ansi16(code-30, false)
+		case code >= 40 && code <= 47:
+			s.bg = ansi16(code-40, false)
+		case code >= 90 && code <= 97:
+			s.fg = ansi16(code-90, true)
+		case code >= 100 && code <= 107:
+			s.bg = ansi16(code-100, true)
+		case code == 38 || code == 48:
+			c, used := ansiExtendedColor(codes[i+1:])
+			if c == nil {
+				break
+			}
+			if code == 38 {
+				s.fg = c
+			} else {
+				s.bg = c
+			}
+			i += used
+		}
+	}
+}
+
+// ansiExtendedColor parses the trailing parameters of a 38/48 SGR code,
+// supporting both the 256-colour form (5;n) and truecolor (2;r;g;b). It
+// returns nil, 0 if the parameters are not a form it understands.
+func ansiExtendedColor(rest []int) (color.Color, int) {
+	if len(rest) == 0 {
+		return nil, 0
+	}
+
+	switch rest[0] {
+	case 5:
+		if len(rest) < 2 {
+			return nil, 0
+		}
+		return ansi256(rest[1]), 2
+	case 2:
+		if len(rest) < 4 {
+			return nil, 0
+		}
+		return color.RGBA{R: uint8(rest[1]), G: uint8(rest[2]), B: uint8(rest[3]), A: 0xff}, 4
+	default:
+		return nil, 0
+	}
+}
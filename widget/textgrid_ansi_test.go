@@ -0,0 +1,78 @@
+package widget
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyANSIEscape(t *testing.T) {
+	grid := NewTextGrid()
+
+	grid.applyANSIEscape([]byte("\x1b[31m"))
+	s := grid.style()
+	assert.Equal(t, color.RGBA{R: 0xcd, G: 0x00, B: 0x00, A: 0xff}, s.fg)
+
+	grid.applyANSIEscape([]byte("\x1b[1m"))
+	assert.True(t, s.bold)
+
+	grid.applyANSIEscape([]byte("\x1b[0m"))
+	assert.Nil(t, s.fg)
+	assert.False(t, s.bold)
+
+	grid.applyANSIEscape([]byte("\x1b[45m"))
+	assert.Equal(t, color.RGBA{R: 0xcd, G: 0x00, B: 0xcd, A: 0xff}, s.bg)
+
+	// Malformed sequences are dropped without touching existing state.
+	grid.applyANSIEscape([]byte("\x1b[9999999999999999999m"))
+	assert.Equal(t, color.RGBA{R: 0xcd, G: 0x00, B: 0xcd, A: 0xff}, s.bg)
+
+	// Anything that isn't a complete SGR ("m") sequence is ignored.
+	grid.applyANSIEscape([]byte("\x1b[2J"))
+	assert.Equal(t, color.RGBA{R: 0xcd, G: 0x00, B: 0xcd, A: 0xff}, s.bg)
+}
+
+func TestAnsiExtendedColor(t *testing.T) {
+	c, used := ansiExtendedColor([]int{5, 196})
+	assert.Equal(t, ansi256(196), c)
+	assert.Equal(t, 2, used)
+
+	c, used = ansiExtendedColor([]int{2, 0x11, 0x22, 0x33})
+	assert.Equal(t, color.RGBA{R: 0x11, G: 0x22, B: 0x33, A: 0xff}, c)
+	assert.Equal(t, 4, used)
+
+	c, used = ansiExtendedColor([]int{2, 0x11}) // truncated truecolor params
+	assert.Nil(t, c)
+	assert.Equal(t, 0, used)
+
+	c, used = ansiExtendedColor([]int{9}) // unknown selector
+	assert.Nil(t, c)
+	assert.Equal(t, 0, used)
+
+	c, used = ansiExtendedColor(nil)
+	assert.Nil(t, c)
+	assert.Equal(t, 0, used)
+}
+
+func TestAnsiEscapeEnd(t *testing.T) {
+	end, ok := ansiEscapeEnd([]byte("\x1b[31m"))
+	assert.True(t, ok)
+	assert.Equal(t, 5, end)
+
+	_, ok = ansiEscapeEnd([]byte("\x1b[31"))
+	assert.False(t, ok) // incomplete - no final byte yet
+
+	end, ok = ansiEscapeEnd([]byte("\x1bc"))
+	assert.True(t, ok)
+	assert.Equal(t, 2, end) // non-CSI two-byte escape is complete immediately
+}
+
+func TestTextGridWriteIsIOWriter(t *testing.T) {
+	grid := NewTextGrid()
+
+	n, err := grid.Write([]byte("hi"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "hi", grid.Text())
+}
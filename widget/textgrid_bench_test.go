@@ -0,0 +1,65 @@
+package widget
+
+import "testing"
+
+// benchGridText returns rows lines of cols runes each, for benchmarking the
+// renderer against a grid of a realistic terminal size.
+func benchGridText(rows, cols int) string {
+	line := make([]rune, cols)
+	for i := range line {
+		line[i] = rune('a' + i%26)
+	}
+	text := string(line)
+
+	out := text
+	for i := 1; i < rows; i++ {
+		out += "\n" + text
+	}
+	return out
+}
+
+func benchGrid(rows, cols int) (*TextGrid, *textGridRender) {
+	grid := NewTextGrid()
+	grid.SetText(benchGridText(rows, cols))
+	render := grid.CreateRenderer().(*textGridRender)
+	render.updateGridSize(grid.Size())
+	render.ensureGrid()
+	render.refreshGrid()
+	return grid, render
+}
+
+// BenchmarkTextGridFullRepaint measures a full Refresh of every cell, as
+// happens the first time a grid is drawn or after SetText.
+func BenchmarkTextGridFullRepaint(b *testing.B) {
+	_, render := benchGrid(80, 200)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		render.refreshGrid()
+	}
+}
+
+// BenchmarkTextGridSingleLineEdit measures the cost of SetRow on one line of
+// a large grid, which should only repaint that row.
+func BenchmarkTextGridSingleLineEdit(b *testing.B) {
+	grid, _ := benchGrid(80, 200)
+	row := grid.Row(40)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		grid.SetRow(40, row)
+	}
+}
+
+// BenchmarkTextGridScrollByOne measures repainting every row shifted by one
+// line, simulating a terminal scrolling its viewport.
+func BenchmarkTextGridScrollByOne(b *testing.B) {
+	grid, render := benchGrid(80, 200)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		first := grid.Content[0]
+		grid.Content = append(grid.Content[1:], first)
+		render.refreshGrid()
+	}
+}
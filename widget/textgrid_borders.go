@@ -0,0 +1,170 @@
+package widget
+
+// borderWeight identifies which family of box-drawing glyphs (light, heavy
+// or double line) a border rune belongs to, so that JoinBorders only
+// connects runes drawn with the same weight.
+type borderWeight int
+
+const (
+	borderNone borderWeight = iota
+	borderLight
+	borderHeavy
+	borderDouble
+)
+
+// borderGlyphs is the set of box-drawing runes needed to draw and join
+// rectangles of a single line weight.
+type borderGlyphs struct {
+	h, v              rune
+	tl, tr, bl, br    rune
+	teeUp, teeDown    rune // ┴ ┬
+	teeLeft, teeRight rune // ┤ ├
+	cross             rune
+}
+
+var borderSets = map[borderWeight]borderGlyphs{
+	borderLight:  {'─', '│', '┌', '┐', '└', '┘', '┴', '┬', '┤', '├', '┼'},
+	borderHeavy:  {'━', '┃', '┏', '┓', '┗', '┛', '┻', '┳', '┫', '┣', '╋'},
+	borderDouble: {'═', '║', '╔', '╗', '╚', '╝', '╩', '╦', '╣', '╠', '╬'},
+}
+
+// borderRuneWeight maps every rune used by borderSets back to its weight, so
+// JoinBorders can recognise box-drawing characters that were placed
+// directly into Content rather than via the Draw* helpers.
+var borderRuneWeight map[rune]borderWeight
+
+func init() {
+	borderRuneWeight = make(map[rune]borderWeight)
+	for weight, g := range borderSets {
+		for _, r := range []rune{g.h, g.v, g.tl, g.tr, g.bl, g.br, g.teeUp, g.teeDown, g.teeLeft, g.teeRight, g.cross} {
+			borderRuneWeight[r] = weight
+		}
+	}
+}
+
+// DrawHLine draws a horizontal light line of the given length starting at
+// (row, col).
+func DrawHLine(grid *TextGrid, row, col, length int, style TextGridStyle) {
+	g := borderSets[borderLight]
+	for i := 0; i < length; i++ {
+		grid.putRune(row, col+i, g.h, style)
+	}
+}
+
+// DrawVLine draws a vertical light line of the given length starting at
+// (row, col).
+func DrawVLine(grid *TextGrid, row, col, length int, style TextGridStyle) {
+	g := borderSets[borderLight]
+	for i := 0; i < length; i++ {
+		grid.putRune(row+i, col, g.v, style)
+	}
+}
+
+// DrawBox draws a w x h rectangle (light line weight) with its top-left
+// corner at (row, col), suitable for framing a panel or a dialog inside a
+// TextGrid. Call JoinBorders afterwards if boxes are drawn touching or
+// overlapping so that shared edges are merged into T and cross glyphs.
+func DrawBox(grid *TextGrid, row, col, w, h int, style TextGridStyle) {
+	if w < 2 || h < 2 {
+		return
+	}
+	g := borderSets[borderLight]
+
+	grid.putRune(row, col, g.tl, style)
+	grid.putRune(row, col+w-1, g.tr, style)
+	grid.putRune(row+h-1, col, g.bl, style)
+	grid.putRune(row+h-1, col+w-1, g.br, style)
+
+	DrawHLine(grid, row, col+1, w-2, style)
+	DrawHLine(grid, row+h-1, col+1, w-2, style)
+	DrawVLine(grid, row+1, col, h-2, style)
+	DrawVLine(grid, row+1, col+w-1, h-2, style)
+}
+
+// DrawTabDivider draws a horizontal light line under a row of tab headers,
+// stamping a down-tee glyph (┬, which opens downwards) at each boundary
+// between consecutive widths, where a vertical line drawn below the rule
+// can join it. widths holds the column width
+// of each tab in order; the divider's total length is their sum.
+func DrawTabDivider(grid *TextGrid, row, col int, widths []int, style TextGridStyle) {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	DrawHLine(grid, row, col, total, style)
+
+	g := borderSets[borderLight]
+	offset := 0
+	for i, w := range widths {
+		offset += w
+		if i < len(widths)-1 {
+			grid.putRune(row, col+offset, g.teeDown, style)
+		}
+	}
+}
+
+// JoinBorders walks the whole grid looking for box-drawing runes (whether
+// placed by DrawBox/DrawHLine/DrawVLine or written directly into Content)
+// and replaces each one with the correct corner, T or cross glyph for its
+// line weight based on which of its four neighbours are also border runes
+// of that same weight. The style already present on each cell is left
+// untouched - only the Rune is rewritten. Box-drawing runes are never
+// treated as the Whitespace placeholder, so this is safe to use on grids
+// with Whitespace enabled.
+func JoinBorders(grid *TextGrid) {
+	for row := range grid.Content {
+		for col := range grid.Content[row] {
+			weight, ok := borderRuneWeight[grid.Content[row][col].Rune]
+			if !ok {
+				continue
+			}
+
+			up := borderNeighbour(grid, row-1, col, weight)
+			down := borderNeighbour(grid, row+1, col, weight)
+			left := borderNeighbour(grid, row, col-1, weight)
+			right := borderNeighbour(grid, row, col+1, weight)
+
+			grid.Content[row][col].Rune = borderGlyph(weight, up, down, left, right)
+		}
+	}
+	grid.refreshAll()
+}
+
+func borderNeighbour(grid *TextGrid, row, col int, weight borderWeight) bool {
+	if row < 0 || col < 0 || row >= len(grid.Content) || col >= len(grid.Content[row]) {
+		return false
+	}
+	w, ok := borderRuneWeight[grid.Content[row][col].Rune]
+	return ok && w == weight
+}
+
+// borderGlyph picks the glyph for a border cell of the given weight based
+// on which of its four neighbours (up/down/left/right) are also borders.
+func borderGlyph(weight borderWeight, up, down, left, right bool) rune {
+	g := borderSets[weight]
+
+	switch {
+	case up && down && left && right:
+		return g.cross
+	case up && down && right:
+		return g.teeRight
+	case up && down && left:
+		return g.teeLeft
+	case left && right && down:
+		return g.teeDown
+	case left && right && up:
+		return g.teeUp
+	case down && right:
+		return g.tl
+	case down && left:
+		return g.tr
+	case up && right:
+		return g.bl
+	case up && left:
+		return g.br
+	case up || down:
+		return g.v
+	default:
+		return g.h
+	}
+}
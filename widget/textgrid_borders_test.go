@@ -0,0 +1,62 @@
+package widget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBorderGlyph(t *testing.T) {
+	g := borderSets[borderLight]
+
+	cases := []struct {
+		up, down, left, right bool
+		want                  rune
+	}{
+		{up: true, down: true, left: true, right: true, want: g.cross},
+		{up: true, down: true, right: true, want: g.teeRight},
+		{up: true, down: true, left: true, want: g.teeLeft},
+		{left: true, right: true, down: true, want: g.teeDown},
+		{left: true, right: true, up: true, want: g.teeUp},
+		{down: true, right: true, want: g.tl},
+		{down: true, left: true, want: g.tr},
+		{up: true, right: true, want: g.bl},
+		{up: true, left: true, want: g.br},
+		{up: true, want: g.v},
+		{down: true, want: g.v},
+		{want: g.h},
+	}
+
+	for _, c := range cases {
+		got := borderGlyph(borderLight, c.up, c.down, c.left, c.right)
+		assert.Equal(t, c.want, got)
+	}
+}
+
+func TestJoinBorders(t *testing.T) {
+	grid := NewTextGrid()
+	DrawBox(grid, 0, 0, 3, 3, nil)
+	DrawBox(grid, 0, 2, 3, 3, nil) // overlaps the first box's right edge
+
+	JoinBorders(grid)
+
+	g := borderSets[borderLight]
+	assert.Equal(t, g.teeDown, grid.Content[0][2].Rune)
+	assert.Equal(t, g.teeUp, grid.Content[2][2].Rune)
+	assert.Equal(t, g.v, grid.Content[1][0].Rune) // box1's left edge - no third box to join here
+}
+
+func TestDrawTabDivider(t *testing.T) {
+	grid := NewTextGrid()
+	DrawTabDivider(grid, 0, 0, []int{4, 5, 3}, nil)
+
+	g := borderSets[borderLight]
+	for col := 0; col < 12; col++ {
+		switch col {
+		case 4, 9:
+			assert.Equal(t, g.teeDown, grid.Content[0][col].Rune)
+		default:
+			assert.Equal(t, g.h, grid.Content[0][col].Rune)
+		}
+	}
+}
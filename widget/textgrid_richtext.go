@@ -0,0 +1,222 @@
+package widget
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/theme"
+)
+
+// RichTextGridStyle is a TextGridStyle that can also request bold or italic
+// rendering. It is the style produced by SetRichText, but can be used
+// directly with SetStyle or SetStyleRange as well.
+type RichTextGridStyle struct {
+	FGColor, BGColor color.Color
+	Bold, Italic     bool
+}
+
+// TextColor is the color a cell should use for the text.
+func (r *RichTextGridStyle) TextColor() color.Color {
+	return r.FGColor
+}
+
+// BackgroundColor is the color a cell should use for the background.
+func (r *RichTextGridStyle) BackgroundColor() color.Color {
+	return r.BGColor
+}
+
+// TextStyle returns the bold/italic attributes this style requests, on top
+// of the monospace font all TextGrid content is drawn with.
+func (r *RichTextGridStyle) TextStyle() fyne.TextStyle {
+	return fyne.TextStyle{Bold: r.Bold, Italic: r.Italic, Monospace: true}
+}
+
+// richTextState is one entry of the tag stack built up while parsing markup,
+// pushed by every tag and restored by "[-]".
+type richTextState struct {
+	fg, bg       color.Color
+	bold, italic bool
+}
+
+func (s richTextState) style() TextGridStyle {
+	if s.fg == nil && s.bg == nil && !s.bold && !s.italic {
+		return nil
+	}
+	return &RichTextGridStyle{FGColor: s.fg, BGColor: s.bg, Bold: s.bold, Italic: s.italic}
+}
+
+// EscapeText returns s with any literal "[" doubled up so that it survives
+// SetRichText unchanged instead of being parsed as the start of a tag.
+func EscapeText(s string) string {
+	return strings.ReplaceAll(s, "[", "[[")
+}
+
+// SetRichText replaces the content of the grid by interpreting markup as
+// text containing inline tags of the form "[fg:bg:flags]", for example
+// "[red]", "[#ff8800]", "[red:blue]", "[:blue]" (background only) and
+// "[primary::b]" (bold, using the theme's primary color). "[-]" restores
+// whatever style was active before the most recent tag, and "[[" inserts a
+// literal "[". Unknown or malformed tags are treated as literal text.
+func (t *TextGrid) SetRichText(markup string) {
+	t.Content = nil
+	stack := []richTextState{{}}
+	row, col := 0, 0
+
+	runes := []rune(markup)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r != '[' {
+			if r == '\n' {
+				row++
+				col = 0
+				continue
+			}
+			t.putRune(row, col, r, stack[len(stack)-1].style())
+			col++
+			continue
+		}
+
+		if i+1 < len(runes) && runes[i+1] == '[' {
+			t.putRune(row, col, '[', stack[len(stack)-1].style())
+			col++
+			i++
+			continue
+		}
+
+		end := -1
+		for j := i + 1; j < len(runes); j++ {
+			if runes[j] == ']' {
+				end = j
+				break
+			}
+		}
+		if end == -1 { // no closing bracket - treat '[' as literal text
+			t.putRune(row, col, '[', stack[len(stack)-1].style())
+			col++
+			continue
+		}
+
+		tag := string(runes[i+1 : end])
+		known := true
+		for f, field := range strings.SplitN(tag, ":", 3) {
+			if f < 2 && field != "" && tag != "-" {
+				if _, ok := namedOrHexColor(field); !ok {
+					known = false
+				}
+			}
+		}
+		if !known { // unknown color - print the '[' and let the rest follow as text
+			t.putRune(row, col, '[', stack[len(stack)-1].style())
+			col++
+			continue
+		}
+		i = end
+
+		if tag == "-" {
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		}
+
+		stack = append(stack, parseRichTextTag(tag, stack[len(stack)-1]))
+	}
+
+	t.refreshAll()
+}
+
+// parseRichTextTag applies a "fg:bg:flags" tag body on top of prev, leaving
+// any field that was left empty unchanged.
+func parseRichTextTag(tag string, prev richTextState) richTextState {
+	next := prev
+	parts := strings.SplitN(tag, ":", 3)
+
+	if len(parts) > 0 && parts[0] != "" {
+		if c, ok := namedOrHexColor(parts[0]); ok {
+			next.fg = c
+		}
+	}
+	if len(parts) > 1 && parts[1] != "" {
+		if c, ok := namedOrHexColor(parts[1]); ok {
+			next.bg = c
+		}
+	}
+	if len(parts) > 2 && parts[2] != "" {
+		next.bold = strings.ContainsRune(parts[2], 'b')
+		next.italic = strings.ContainsRune(parts[2], 'i')
+	}
+
+	return next
+}
+
+// namedOrHexColor resolves a tag color field, either a "#rgb"/"#rrggbb" hex
+// literal or a name resolved through the current theme.
+func namedOrHexColor(field string) (color.Color, bool) {
+	if strings.HasPrefix(field, "#") {
+		return parseHexColor(field)
+	}
+
+	switch strings.ToLower(field) {
+	case "black":
+		return ansi16(0, false), true
+	case "red":
+		return ansi16(1, false), true
+	case "green":
+		return ansi16(2, false), true
+	case "yellow":
+		return ansi16(3, false), true
+	case "blue":
+		return ansi16(4, false), true
+	case "magenta", "fuchsia":
+		return ansi16(5, false), true
+	case "cyan", "aqua":
+		return ansi16(6, false), true
+	case "white":
+		return ansi16(7, false), true
+	case "gray", "grey":
+		return ansi16(8, false), true
+	case "primary":
+		return theme.PrimaryColor(), true
+	case "focus":
+		return theme.FocusColor(), true
+	case "background":
+		return theme.BackgroundColor(), true
+	case "text":
+		return theme.TextColor(), true
+	default:
+		return nil, false
+	}
+}
+
+// parseHexColor parses "#rgb" or "#rrggbb" into an opaque color.
+func parseHexColor(s string) (color.Color, bool) {
+	s = strings.TrimPrefix(s, "#")
+
+	expand := func(h string) (uint8, bool) {
+		if len(h) == 1 {
+			h = h + h
+		}
+		v, err := strconv.ParseUint(h, 16, 8)
+		return uint8(v), err == nil
+	}
+
+	var rs, gs, bs string
+	switch len(s) {
+	case 3:
+		rs, gs, bs = s[0:1], s[1:2], s[2:3]
+	case 6:
+		rs, gs, bs = s[0:2], s[2:4], s[4:6]
+	default:
+		return nil, false
+	}
+
+	r, ok1 := expand(rs)
+	g, ok2 := expand(gs)
+	b, ok3 := expand(bs)
+	if !ok1 || !ok2 || !ok3 {
+		return nil, false
+	}
+	return color.RGBA{R: r, G: g, B: b, A: 0xff}, true
+}
@@ -0,0 +1,59 @@
+package widget
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHexColor(t *testing.T) {
+	c, ok := parseHexColor("#f80")
+	assert.True(t, ok)
+	assert.Equal(t, color.RGBA{R: 0xff, G: 0x88, B: 0x00, A: 0xff}, c)
+
+	c, ok = parseHexColor("#ff8800")
+	assert.True(t, ok)
+	assert.Equal(t, color.RGBA{R: 0xff, G: 0x88, B: 0x00, A: 0xff}, c)
+
+	_, ok = parseHexColor("#ff88")
+	assert.False(t, ok) // not a valid 3 or 6 digit form
+
+	_, ok = parseHexColor("#gggggg")
+	assert.False(t, ok)
+}
+
+func TestParseRichTextTag(t *testing.T) {
+	next := parseRichTextTag("red:blue", richTextState{})
+	assert.Equal(t, ansi16(1, false), next.fg)
+	assert.Equal(t, ansi16(4, false), next.bg)
+	assert.False(t, next.bold)
+	assert.False(t, next.italic)
+
+	next = parseRichTextTag("#ff8800::bi", richTextState{})
+	assert.Equal(t, color.RGBA{R: 0xff, G: 0x88, B: 0x00, A: 0xff}, next.fg)
+	assert.Nil(t, next.bg)
+	assert.True(t, next.bold)
+	assert.True(t, next.italic)
+
+	// An empty field leaves the inherited value from prev untouched.
+	prev := richTextState{fg: ansi16(2, false), bold: true}
+	next = parseRichTextTag(":blue", prev)
+	assert.Equal(t, ansi16(2, false), next.fg)
+	assert.Equal(t, ansi16(4, false), next.bg)
+	assert.True(t, next.bold)
+}
+
+func TestEscapeText(t *testing.T) {
+	assert.Equal(t, "hello [[world]", EscapeText("hello [world]"))
+}
+
+func TestSetRichText(t *testing.T) {
+	grid := NewTextGrid()
+	grid.SetRichText("plain[red]red[-]plain")
+
+	assert.Equal(t, "plainredplain", grid.Text())
+	assert.Nil(t, grid.Content[0][0].Style)
+	assert.Equal(t, ansi16(1, false), grid.Content[0][5].Style.TextColor())
+	assert.Nil(t, grid.Content[0][8].Style)
+}
@@ -0,0 +1,251 @@
+package widget
+
+import (
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne"
+	"fyne.io/fyne/driver/desktop"
+	"fyne.io/fyne/theme"
+)
+
+// textGridRegion marks a named, clickable range of cells within a TextGrid -
+// mirroring tview's regions, this is useful for clickable log lines,
+// hyperlinks or inline buttons rendered as plain text.
+type textGridRegion struct {
+	name                               string
+	startRow, startCol, endRow, endCol int
+}
+
+func (r textGridRegion) contains(row, col int) bool {
+	if row < r.startRow || row > r.endRow {
+		return false
+	}
+	if row == r.startRow && col < r.startCol {
+		return false
+	}
+	if row == r.endRow && col > r.endCol {
+		return false
+	}
+	return true
+}
+
+// gridSelection is the range of cells currently highlighted by a
+// click-drag, stored as (row, visual column) coordinates - the same visual
+// columns cellForPosition and paintRow use, so a wide (e.g. CJK) rune counts
+// as two columns. SelectedText translates these back to buffer indices with
+// visualColumnIndex before slicing Content.
+type gridSelection struct {
+	startRow, startCol, endRow, endCol int
+	active                             bool
+}
+
+// normalized returns the selection with its start before its end, regardless
+// of which direction it was dragged in.
+func (s gridSelection) normalized() (startRow, startCol, endRow, endCol int) {
+	if s.startRow < s.endRow || (s.startRow == s.endRow && s.startCol <= s.endCol) {
+		return s.startRow, s.startCol, s.endRow, s.endCol
+	}
+	return s.endRow, s.endCol, s.startRow, s.startCol
+}
+
+func (s gridSelection) contains(row, col int) bool {
+	if !s.active {
+		return false
+	}
+	startRow, startCol, endRow, endCol := s.normalized()
+	if row < startRow || row > endRow {
+		return false
+	}
+	if row == startRow && col < startCol {
+		return false
+	}
+	if row == endRow && col > endCol {
+		return false
+	}
+	return true
+}
+
+// selectionStyle wraps a cell's existing style so the selection highlight
+// can replace the background while preserving the foreground (and any
+// bold/italic text styling) the cell already had.
+type selectionStyle struct {
+	base TextGridStyle
+}
+
+func (s selectionStyle) TextColor() color.Color {
+	if s.base != nil && s.base.TextColor() != nil {
+		return s.base.TextColor()
+	}
+	return theme.TextColor()
+}
+
+func (s selectionStyle) BackgroundColor() color.Color {
+	return theme.FocusColor()
+}
+
+func (s selectionStyle) TextStyle() fyne.TextStyle {
+	if styled, ok := s.base.(interface{ TextStyle() fyne.TextStyle }); ok {
+		return styled.TextStyle()
+	}
+	return fyne.TextStyle{Monospace: true}
+}
+
+// SetRegion marks the cells from (startRow, startCol) to (endRow, endCol),
+// inclusive, as belonging to a named region. Setting a region with a name
+// that already exists replaces its range. A region can later be found with
+// RegionAt, and OnRegionTapped fires when the user clicks inside one.
+func (t *TextGrid) SetRegion(name string, startRow, startCol, endRow, endCol int) {
+	region := textGridRegion{name: name, startRow: startRow, startCol: startCol, endRow: endRow, endCol: endCol}
+
+	for i, r := range t.regions {
+		if r.name == name {
+			t.regions[i] = region
+			return
+		}
+	}
+	t.regions = append(t.regions, region)
+}
+
+// RegionAt returns the name of the region containing (row, col), or an
+// empty string if no region covers that cell.
+func (t *TextGrid) RegionAt(row, col int) string {
+	for _, r := range t.regions {
+		if r.contains(row, col) {
+			return r.name
+		}
+	}
+	return ""
+}
+
+// cellForPosition converts a position relative to the grid's top-left
+// corner into the (row, col) of the cell it falls within. col is a visual
+// column of the row's content: the line-number gutter, if shown, is not
+// counted, so a position inside the gutter gives a negative col.
+func (t *TextGrid) cellForPosition(pos fyne.Position) (row, col int) {
+	w, h := t.cellSize.Width, t.cellSize.Height
+	if w <= 0 {
+		w = 1
+	}
+	if h <= 0 {
+		h = 1
+	}
+	row, col = int(pos.Y/h), int(pos.X/w)
+	if t.LineNumbers && t.renderer != nil {
+		col -= t.renderer.lineCountWidth() + 1 // digits, padding and the '|' separator
+	}
+	return row, col
+}
+
+// MouseDown starts a new selection at the clicked cell, and also triggers
+// OnRegionTapped if the cell belongs to a region. It also requests focus, so
+// that a following Ctrl+C reaches TypedShortcut.
+func (t *TextGrid) MouseDown(ev *desktop.MouseEvent) {
+	if canvas := fyne.CurrentApp().Driver().CanvasForObject(t); canvas != nil {
+		canvas.Focus(t)
+	}
+
+	row, col := t.cellForPosition(ev.Position)
+
+	if name := t.RegionAt(row, col); name != "" && t.OnRegionTapped != nil {
+		t.OnRegionTapped(name)
+	}
+
+	t.selection = gridSelection{startRow: row, startCol: col, endRow: row, endCol: col, active: true}
+	t.refreshAll()
+}
+
+// MouseUp ends the current mouse press. The selection remains visible and
+// copyable until the user starts a new click or drag.
+func (t *TextGrid) MouseUp(ev *desktop.MouseEvent) {
+}
+
+// Dragged extends the active selection to follow the pointer.
+func (t *TextGrid) Dragged(ev *fyne.DragEvent) {
+	row, col := t.cellForPosition(ev.Position)
+	if !t.selection.active {
+		t.selection = gridSelection{startRow: row, startCol: col, active: true}
+	}
+
+	t.selection.endRow, t.selection.endCol = row, col
+	t.refreshAll()
+}
+
+// DragEnd finishes a drag selection; the range stays selected.
+func (t *TextGrid) DragEnd() {
+}
+
+// SelectedText returns the text currently highlighted by a click-drag
+// selection, joining wrapped lines with "\n" the same way Text does.
+func (t *TextGrid) SelectedText() string {
+	if !t.selection.active {
+		return ""
+	}
+	startRow, startCol, endRow, endCol := t.selection.normalized()
+
+	var b strings.Builder
+	for row := startRow; row <= endRow && row < len(t.Content); row++ {
+		content := t.Content[row]
+		from, to := 0, len(content)
+		if row == startRow {
+			from = visualColumnIndex(content, startCol)
+		}
+		if row == endRow {
+			if endIdx := visualColumnIndex(content, endCol); endIdx+1 < to {
+				to = endIdx + 1
+			}
+		}
+		if from < 0 {
+			from = 0
+		}
+		if from > len(content) {
+			from = len(content)
+		}
+		if to > len(content) {
+			to = len(content)
+		}
+
+		for _, cell := range content[from:to] {
+			b.WriteRune(cell.Rune)
+		}
+		if row < endRow {
+			b.WriteRune('\n')
+		}
+	}
+	return b.String()
+}
+
+// TypedShortcut copies the current selection to the clipboard on Ctrl+C (or
+// Cmd+C on macOS). The canvas only dispatches this to the focused object, so
+// MouseDown requests focus for the grid first.
+func (t *TextGrid) TypedShortcut(shortcut fyne.Shortcut) {
+	cpy, ok := shortcut.(*fyne.ShortcutCopy)
+	if !ok {
+		return
+	}
+
+	text := t.SelectedText()
+	if text == "" {
+		return
+	}
+	cpy.Clipboard.SetContent(text)
+}
+
+// FocusGained is called when this TextGrid becomes the focused object on
+// its canvas, which MouseDown requests on click. There is currently no
+// visual focus indicator; this only exists so TextGrid satisfies
+// fyne.Focusable and can therefore receive TypedShortcut.
+func (t *TextGrid) FocusGained() {
+}
+
+// FocusLost is called when this TextGrid stops being the focused object.
+func (t *TextGrid) FocusLost() {
+}
+
+// TypedRune is a no-op; TextGrid does not accept typed text input.
+func (t *TextGrid) TypedRune(rune) {
+}
+
+// TypedKey is a no-op; TextGrid does not accept typed key input.
+func (t *TextGrid) TypedKey(*fyne.KeyEvent) {
+}
@@ -0,0 +1,90 @@
+package widget
+
+import (
+	"testing"
+
+	"fyne.io/fyne"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClipboard is a minimal fyne.Clipboard for testing TypedShortcut
+// without a running app or window.
+type fakeClipboard struct {
+	content string
+}
+
+func (c *fakeClipboard) Content() string           { return c.content }
+func (c *fakeClipboard) SetContent(content string) { c.content = content }
+
+func TestGridSelectionNormalized(t *testing.T) {
+	s := gridSelection{startRow: 3, startCol: 5, endRow: 1, endCol: 2, active: true}
+	startRow, startCol, endRow, endCol := s.normalized()
+	assert.Equal(t, 1, startRow)
+	assert.Equal(t, 2, startCol)
+	assert.Equal(t, 3, endRow)
+	assert.Equal(t, 5, endCol)
+
+	assert.True(t, s.contains(2, 0))
+	assert.False(t, s.contains(0, 0))
+	assert.False(t, gridSelection{}.contains(0, 0)) // inactive selection contains nothing
+}
+
+func TestTextGridRegion(t *testing.T) {
+	grid := NewTextGrid()
+	grid.SetRegion("link", 0, 2, 0, 5)
+
+	assert.Equal(t, "link", grid.RegionAt(0, 3))
+	assert.Equal(t, "", grid.RegionAt(0, 6))
+	assert.Equal(t, "", grid.RegionAt(1, 3))
+
+	grid.SetRegion("link", 1, 0, 1, 1) // re-setting a name replaces its range
+	assert.Equal(t, "", grid.RegionAt(0, 3))
+	assert.Equal(t, "link", grid.RegionAt(1, 0))
+}
+
+func TestSelectedText(t *testing.T) {
+	grid := NewTextGrid()
+	grid.SetText("hello\nworld")
+
+	grid.selection = gridSelection{startRow: 0, startCol: 1, endRow: 1, endCol: 2, active: true}
+	assert.Equal(t, "ello\nwor", grid.SelectedText())
+
+	grid.selection = gridSelection{}
+	assert.Equal(t, "", grid.SelectedText())
+}
+
+func TestSelectedTextWithWideRunes(t *testing.T) {
+	grid := NewTextGrid()
+	grid.SetText("a中文b") // a, 2 wide CJK runes, b - visual columns 0,1-2,3-4,5
+
+	// Select visual columns 3-5, the second wide rune through 'b'.
+	grid.selection = gridSelection{startRow: 0, startCol: 3, endRow: 0, endCol: 5, active: true}
+	assert.Equal(t, "文b", grid.SelectedText())
+}
+
+func TestTextGridTypedShortcutCopiesSelection(t *testing.T) {
+	grid := NewTextGrid()
+	grid.SetText("hello\nworld")
+	grid.selection = gridSelection{startRow: 0, startCol: 0, endRow: 0, endCol: 4, active: true}
+
+	// FocusGained/FocusLost only need to exist to satisfy fyne.Focusable -
+	// call them the way a canvas would around the TypedShortcut dispatch.
+	grid.FocusGained()
+	clip := &fakeClipboard{}
+	grid.TypedShortcut(&fyne.ShortcutCopy{Clipboard: clip})
+	grid.FocusLost()
+
+	assert.Equal(t, "hello", clip.Content())
+}
+
+func TestTextGridTypedShortcutIgnoresOtherShortcuts(t *testing.T) {
+	grid := NewTextGrid()
+	grid.SetText("hello")
+	grid.selection = gridSelection{startRow: 0, startCol: 0, endRow: 0, endCol: 4, active: true}
+
+	clip := &fakeClipboard{}
+	grid.TypedShortcut(&fyne.ShortcutPaste{Clipboard: clip})
+	// the type assertion in TypedShortcut fails and it returns without
+	// copying the selection to the clipboard.
+	assert.Equal(t, "", clip.Content())
+}